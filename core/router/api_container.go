@@ -16,29 +16,99 @@ type APIContainer struct {
 
 	// Container is the per-party (and its children gets a clone) DI container..
 	Container *hero.Container
+
+	// handlerValidation controls whether handlers registered through this APIContainer
+	// are walked and cross-checked against the Container's Dependencies at registration time.
+	// Defaults to `ValidationOff`, i.e. the original, request-time-only behavior.
+	// See `SetHandlerValidation`.
+	handlerValidation HandlerValidationMode
+
+	// parent is the APIContainer this one was created from through `Party`, used so that
+	// error mappings registered on an ancestor Party are inherited by its children.
+	// It's nil for the root APIContainer of an Application.
+	parent *APIContainer
+
+	// errorMappings holds the error-to-status mappings registered through `MapError`
+	// and `MapErrorType`, tried in registration order before falling back to `GetErrorHandler`.
+	errorMappings []*errorMapping
+
+	// fallbackErrorHandler is whatever was previously installed through `OnError`,
+	// it's always tried after `errorMappings` found no match.
+	fallbackErrorHandler func(context.Context) hero.ErrorHandler
+
+	// pendingMiddleware holds `Use`/`UseAt` registrations queued since the last flush,
+	// committed in order before every route registration (`Handle`, `Any`, `Party`, `Mount`).
+	pendingMiddleware []orderedMiddleware
+	// nextMiddlewareOrder is the largest order queued so far; the next `Use` (as opposed
+	// to `UseAt`) call gets it plus `middlewareOrderStep`.
+	nextMiddlewareOrder int
+
+	// routes remembers every *Route registered through this APIContainer together with
+	// the reflect.Type of its handler, consumed by `OpenAPI` and `Describe`.
+	routes []*routeRegistration
+	// children holds every APIContainer created from this one through `Party`, so that
+	// `OpenAPI` can recurse into them instead of only describing this Party's own routes.
+	children []*APIContainer
+	// openAPITitle and openAPIVersion customize the "info" object `OpenAPI` emits.
+	// See `SetOpenAPIInfo`.
+	openAPITitle, openAPIVersion string
+
+	// scopedFinalizers holds every `(*ScopedDependency).Finalizer` registered so far.
+	scopedFinalizers []scopedFinalizer
+	// scopedFinalizerHookInstalled reports whether the `Done` handler that runs
+	// scopedFinalizers has already been installed on Self.
+	scopedFinalizerHookInstalled bool
+}
+
+// middlewareOrderStep is the gap left between two consecutive `Use` calls' orders,
+// so that `UseAt` can still slot handlers in between them.
+const middlewareOrderStep = 1000
+
+// SetHandlerValidation sets the `HandlerValidationMode` for this APIContainer,
+// controlling what happens when a handler registered through `Handle`, `Get`,
+// `Post`, `Any` and the rest cannot be satisfied by the Party's registered
+// `Container.Dependencies`:
+//   - `ValidationOff` (default): no extra checks, the original reflect-based
+//     error is only returned at first request time.
+//   - `ValidationWarn`: the same checks run at registration time but only log
+//     a warning through the Party's logger, the server still starts.
+//   - `ValidationStrict`: registering the handler panics with a `*HandlerValidationError`
+//     naming the exact handler, parameter and closest registered dependency.
+//
+// It's propagated to children Parties created through `APIContainer.Party`
+// unless they call `SetHandlerValidation` themselves.
+func (api *APIContainer) SetHandlerValidation(mode HandlerValidationMode) *APIContainer {
+	api.handlerValidation = mode
+	return api
 }
 
 // Party returns a child of this `APIContainer` featured with Dependency Injection.
 // Like the `Self.Party` method does for the common Router Groups.
 func (api *APIContainer) Party(relativePath string, handlersFn ...interface{}) *APIContainer {
+	api.flushMiddleware()
 	handlers := api.convertHandlerFuncs(relativePath, handlersFn...)
 	p := api.Self.Party(relativePath, handlers...)
-	return p.ConfigureContainer()
+	child := p.ConfigureContainer()
+	child.handlerValidation = api.handlerValidation
+	child.parent = api
+	api.children = append(api.children, child)
+	return child
 }
 
 // OnError adds an error handler for this Party's DI Hero Container and its handlers (or controllers).
 // The "errorHandler" handles any error may occurred and returned
 // during dependencies injection of the Party's hero handlers or from the handlers themselves.
 //
-// Same as:
-// Container.GetErrorHandler = func(ctx iris.Context) hero.ErrorHandler { return errorHandler }
+// It's always tried after the mappings registered through `MapError` and `MapErrorType`
+// found no match for the returned error.
 //
-// See `RegisterDependency`, `Use`, `Done` and `Handle` too.
+// See `MapError`, `MapErrorType`, `RegisterDependency`, `Use`, `Done` and `Handle` too.
 func (api *APIContainer) OnError(errorHandler func(context.Context, error)) {
 	errHandler := hero.ErrorHandlerFunc(errorHandler)
-	api.Container.GetErrorHandler = func(ctx context.Context) hero.ErrorHandler {
+	api.fallbackErrorHandler = func(ctx context.Context) hero.ErrorHandler {
 		return errHandler
 	}
+	api.Container.GetErrorHandler = api.resolveErrorHandler
 }
 
 // RegisterDependency adds a dependency.
@@ -78,6 +148,16 @@ func (api *APIContainer) convertHandlerFuncs(relativePath string, handlersFn ...
 
 	handlers := make(context.Handlers, 0, len(handlersFn))
 	for _, h := range handlersFn {
+		if api.handlerValidation != ValidationOff {
+			if err := api.preCheckHandler(h, fullpath, paramsCount); err != nil {
+				if api.handlerValidation == ValidationStrict {
+					panic(err)
+				}
+
+				api.Self.Logger().Warn(err)
+			}
+		}
+
 		handlers = append(handlers, api.Container.HandlerWithParams(h, paramsCount))
 	}
 
@@ -91,10 +171,17 @@ func (api *APIContainer) convertHandlerFuncs(relativePath string, handlersFn ...
 
 // Use same as `Self.Use` but it accepts dynamic functions as its "handlersFn" input.
 //
-// See `OnError`, `RegisterDependency`, `Done` and `Handle` for more.
+// See `OnError`, `RegisterDependency`, `Done`, `UseAt` and `Handle` for more.
 func (api *APIContainer) Use(handlersFn ...interface{}) {
 	handlers := api.convertHandlerFuncs("/", handlersFn...)
-	api.Self.Use(handlers...)
+	api.appendMiddleware(handlers)
+}
+
+// appendMiddleware queues "handlers" at the end of the chain registered so far,
+// i.e. at an order past every order a previous `UseAt` call may have used.
+func (api *APIContainer) appendMiddleware(handlers context.Handlers) {
+	api.nextMiddlewareOrder += middlewareOrderStep
+	api.queueMiddleware(api.nextMiddlewareOrder, handlers)
 }
 
 // Done same as `Self.Done` but it accepts dynamic functions as its "handlersFn" input.
@@ -116,8 +203,11 @@ func (api *APIContainer) Done(handlersFn ...interface{}) {
 //
 // See `OnError`, `RegisterDependency`, `Use`, `Done`, `Get`, `Post`, `Put`, `Patch` and `Delete` too.
 func (api *APIContainer) Handle(method, relativePath string, handlersFn ...interface{}) *Route {
+	api.flushMiddleware()
 	handlers := api.convertHandlerFuncs(relativePath, handlersFn...)
-	return api.Self.Handle(method, relativePath, handlers...)
+	route := api.Self.Handle(method, relativePath, handlers...)
+	api.trackRoute(route, handlersFn...)
+	return route
 }
 
 // Get registers a route for the Get HTTP Method.
@@ -194,6 +284,7 @@ func (api *APIContainer) Trace(relativePath string, handlersFn ...interface{}) *
 // Connect
 // Trace
 func (api *APIContainer) Any(relativePath string, handlersFn ...interface{}) (routes []*Route) {
+	api.flushMiddleware()
 	handlers := api.convertHandlerFuncs(relativePath, handlersFn...)
 
 	for _, m := range AllMethods {
@@ -201,5 +292,9 @@ func (api *APIContainer) Any(relativePath string, handlersFn ...interface{}) (ro
 		routes = append(routes, r...)
 	}
 
+	for _, r := range routes {
+		api.trackRoute(r, handlersFn...)
+	}
+
 	return
 }
@@ -0,0 +1,186 @@
+package router
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/kataras/iris/v12/context"
+)
+
+// errorStashKey is the `context.Context` Values key `resolveErrorHandler` stashes the
+// returned error under, right before rendering it, so that an `Around` middleware's
+// "next" function can observe whatever error a handler further down the chain produced.
+const errorStashKey = "iris.router.around.error"
+
+var nextFuncType = reflect.TypeOf((func() error)(nil))
+
+// orderedMiddleware is a pending `Use`/`UseAt` registration, kept unapplied until this
+// Party's next route (or child Party) is registered, so that `UseAt` can still place
+// middleware before handlers that were converted, but not yet committed, earlier.
+type orderedMiddleware struct {
+	order    int
+	handlers context.Handlers
+}
+
+// Around registers a DI-aware middleware that runs both before and after the rest of
+// the handler chain, with access to the same dependencies a regular hero handler would
+// resolve from this Party's Container. "fn" must have the signature:
+//
+//	func(deps..., next func() error) error
+//
+// The framework calls "next" in place of `ctx.Next()`; it runs the remaining handler
+// chain and returns whatever error that chain produced (including one raised by a
+// deeper `Around` or surfaced through `MapError`/`MapErrorType`) - that error has
+// already been rendered by the time "next" returns it. "next" only sees that error
+// once this Party's error mapper is installed (by `OnError`, `MapError` or
+// `MapErrorType`); without it, "next" always returns nil. "fn" returning it back
+// unchanged, as the documented usage below does, only reports it to whatever called
+// "fn"; it is not rendered a second time. Returning a different, new error from "fn"
+// is resolved through the same error mapper `OnError`/`MapError` use, exactly as a
+// regular hero handler's returned error would be.
+//
+// Usage:
+//
+//	api.Around(func(logger *Logger, next func() error) error {
+//	    started := time.Now()
+//	    err := next()
+//	    logger.Printf("request took %s, err=%v", time.Since(started), err)
+//	    return err
+//	})
+//
+// See `UseAt`, `Use`, `MapError` and `OnError` too.
+func (api *APIContainer) Around(fn interface{}) *APIContainer {
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func || typ.NumIn() == 0 || typ.In(typ.NumIn()-1) != nextFuncType {
+		panic("iris: Around: fn must be a func with a final `next func() error` parameter")
+	}
+
+	api.Container.Register(func(ctx context.Context) func() error {
+		return func() error {
+			ctx.Next()
+			if err, ok := ctx.Values().Get(errorStashKey).(error); ok {
+				return err
+			}
+
+			return nil
+		}
+	})
+
+	// "fn" is itself dispatched through `Container.HandlerWithParams` like any hero
+	// handler, so whatever error it returns would otherwise be handed straight back to
+	// `resolveErrorHandler` and rendered again - garbling the response (or panicking
+	// with "superfluous WriteHeader") the moment "fn" forwards an error "next" already
+	// rendered, exactly as the documented `return err` usage above does. aroundHandler
+	// wraps "fn" with a leading `context.Context` parameter so it can compare the
+	// returned error against the one stashed by the nested `ctx.Next()` call and
+	// swallow it when it's the very same error, letting a genuinely new error from
+	// "fn" itself go through to be rendered as usual.
+	wrapped := aroundHandler(fn)
+	handler := api.Container.HandlerWithParams(wrapped, 0)
+	api.appendMiddleware(context.Handlers{handler})
+	return api
+}
+
+// aroundHandler wraps "fn" (an `Around` callback) into an equivalent func that also
+// accepts a leading `context.Context`, so the wrapper can tell whether the error "fn"
+// returns is the same one `errorStashKey` already holds - i.e. one "next" forwarded
+// and the error mapper already rendered - and suppress it rather than let it be
+// dispatched, and rendered, a second time.
+func aroundHandler(fn interface{}) interface{} {
+	fnType := reflect.TypeOf(fn)
+	fnValue := reflect.ValueOf(fn)
+
+	in := make([]reflect.Type, fnType.NumIn()+1)
+	in[0] = contextType
+	for i := 0; i < fnType.NumIn(); i++ {
+		in[i+1] = fnType.In(i)
+	}
+
+	wrapperType := reflect.FuncOf(in, []reflect.Type{errType}, false)
+
+	return reflect.MakeFunc(wrapperType, func(args []reflect.Value) []reflect.Value {
+		ctx := args[0].Interface().(context.Context)
+
+		out := fnValue.Call(args[1:])
+		err, _ := out[0].Interface().(error)
+
+		// Read *after* calling "fn": this is what "next" (called from within "fn")
+		// just stashed via the nested `ctx.Next()` dispatch, if the rest of the chain
+		// errored.
+		forwarded, _ := ctx.Values().Get(errorStashKey).(error)
+
+		result := reflect.New(errType).Elem()
+		if err := suppressForwardedError(err, forwarded); err != nil {
+			result.Set(reflect.ValueOf(err))
+		}
+
+		return []reflect.Value{result}
+	}).Interface()
+}
+
+// suppressForwardedError reports the error an `Around` handler's wrapper should
+// actually dispatch: nil when "err" is exactly "forwarded" - i.e. "fn" only handed
+// back the same error "next" already rendered - and "err" itself otherwise, whether
+// that's a brand new error "fn" produced or a nil one.
+func suppressForwardedError(err, forwarded error) error {
+	if err != nil && err == forwarded {
+		return nil
+	}
+
+	return err
+}
+
+// UseAt is the same as `Use` but it inserts "handlersFn" at the given "order" instead of
+// always appending them to the end of the chain. Middleware registered through `Use` is
+// treated as if registered at the largest order seen so far, so cross-cutting concerns
+// (tracing, auth) can still be placed ahead of it by passing a smaller "order", even if
+// the call to `UseAt` itself happens later.
+//
+// The queued middleware is committed to the underlying Party on its next route
+// registration (`Handle`, `Any`, `Party` or `Mount`), sorted by "order" (stable, so
+// middleware sharing an order keeps its registration order) against the other
+// middleware queued since the previous registration.
+//
+// See `Use`, `Around` and `Done` too.
+func (api *APIContainer) UseAt(order int, handlersFn ...interface{}) {
+	handlers := api.convertHandlerFuncs("/", handlersFn...)
+	api.queueMiddleware(order, handlers)
+}
+
+func (api *APIContainer) queueMiddleware(order int, handlers context.Handlers) {
+	if order > api.nextMiddlewareOrder {
+		api.nextMiddlewareOrder = order
+	}
+
+	api.pendingMiddleware = append(api.pendingMiddleware, orderedMiddleware{order: order, handlers: handlers})
+}
+
+// flushMiddleware sorts and commits whatever middleware is currently queued through
+// `Use`/`UseAt` to the underlying Party, then empties the queue. It's called before
+// every route registration (`Handle`, `Any`, `Party`, `Mount`), not just the first one: a
+// `Use`/`UseAt` call made between two route registrations still needs to be committed,
+// it just sorts against the other middleware queued since the previous flush rather
+// than against middleware already committed to Self in an earlier flush.
+func (api *APIContainer) flushMiddleware() {
+	for _, m := range api.popPendingMiddleware() {
+		api.Self.Use(m.handlers...)
+	}
+}
+
+// popPendingMiddleware returns the middleware queued since the last flush, sorted by
+// "order" (stable), and empties the queue so a later `Use`/`UseAt` call starts a fresh
+// batch rather than being silently merged into, or dropped after, an earlier one.
+func (api *APIContainer) popPendingMiddleware() []orderedMiddleware {
+	if len(api.pendingMiddleware) == 0 {
+		return nil
+	}
+
+	pending := api.pendingMiddleware
+	api.pendingMiddleware = nil
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].order < pending[j].order
+	})
+
+	return pending
+}
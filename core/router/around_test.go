@@ -0,0 +1,94 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kataras/iris/v12/context"
+)
+
+func TestPopPendingMiddlewareSortsByOrder(t *testing.T) {
+	api := &APIContainer{}
+
+	api.queueMiddleware(20, context.Handlers{nil})
+	api.queueMiddleware(10, context.Handlers{nil})
+
+	pending := api.popPendingMiddleware()
+	if len(pending) != 2 || pending[0].order != 10 || pending[1].order != 20 {
+		t.Fatalf("expected UseAt's order to be honored regardless of registration order, got %+v", pending)
+	}
+
+	if got := len(api.pendingMiddleware); got != 0 {
+		t.Fatalf("expected the queue to be emptied after popping, got %d entries left", got)
+	}
+}
+
+// TestSuppressForwardedErrorSwallowsWhatNextAlreadyRendered guards against the
+// double-render bug: "fn" forwarding the exact error `next()` gave it (the
+// documented `err := next(); ...; return err` pattern) must not be dispatched to
+// the error mapper again - it was already rendered once, by the time `next()`
+// returned it.
+func TestSuppressForwardedErrorSwallowsWhatNextAlreadyRendered(t *testing.T) {
+	rendered := errors.New("downstream handler failed")
+
+	if got := suppressForwardedError(rendered, rendered); got != nil {
+		t.Fatalf("expected the forwarded error to be suppressed, got %v", got)
+	}
+}
+
+// TestSuppressForwardedErrorLetsNewErrorsThrough guards the opposite case: an error
+// "fn" produces itself - whether the chain below it succeeded or failed with a
+// different error - is a new error that still needs to reach the error mapper.
+func TestSuppressForwardedErrorLetsNewErrorsThrough(t *testing.T) {
+	rendered := errors.New("downstream handler failed")
+	ownErr := errors.New("fn's own error")
+
+	if got := suppressForwardedError(ownErr, rendered); got != ownErr {
+		t.Fatalf("expected fn's own, different error to be returned, got %v", got)
+	}
+
+	if got := suppressForwardedError(ownErr, nil); got != ownErr {
+		t.Fatalf("expected fn's own error to be returned when next() didn't error, got %v", got)
+	}
+
+	if got := suppressForwardedError(nil, rendered); got != nil {
+		t.Fatalf("expected a nil error from fn to stay nil, got %v", got)
+	}
+}
+
+// TestAroundHandlerBuildsWrapperWithLeadingContext checks aroundHandler's reflect
+// plumbing: the wrapper must accept fn's original parameters shifted by one, with a
+// leading context.Context, and return a single error.
+func TestAroundHandlerBuildsWrapperWithLeadingContext(t *testing.T) {
+	fn := func(next func() error) error { return next() }
+
+	wrapped := aroundHandler(fn)
+	wrappedType := reflect.TypeOf(wrapped)
+
+	if wrappedType.NumIn() != 2 || wrappedType.In(0) != contextType || wrappedType.In(1) != nextFuncType {
+		t.Fatalf("unexpected wrapper signature: %s", wrappedType)
+	}
+
+	if wrappedType.NumOut() != 1 || wrappedType.Out(0) != errType {
+		t.Fatalf("expected the wrapper to return a single error, got %s", wrappedType)
+	}
+}
+
+func TestPopPendingMiddlewareDoesNotPermanentlyLatch(t *testing.T) {
+	api := &APIContainer{}
+
+	// First batch, as if queued before the first route registration.
+	api.queueMiddleware(1, context.Handlers{nil})
+	if pending := api.popPendingMiddleware(); len(pending) != 1 {
+		t.Fatalf("expected the first batch to be poppable, got %+v", pending)
+	}
+
+	// A second batch queued *after* the first pop (i.e. between two route
+	// registrations) must still be poppable - a permanent "already flushed" latch
+	// would silently drop it instead.
+	api.queueMiddleware(1, context.Handlers{nil})
+	if pending := api.popPendingMiddleware(); len(pending) != 1 {
+		t.Fatalf("expected a later batch to still be poppable, got %+v", pending)
+	}
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/hero"
+)
+
+// errorMapping associates a predicate over a returned error with the HTTP status
+// code and optional custom renderer that should complete the response when it matches.
+// See `APIContainer.MapError` and `APIContainer.MapErrorType`.
+type errorMapping struct {
+	matches  func(error) bool
+	status   int
+	renderer func(context.Context, error)
+	// noop marks a mapping that must not touch the response at all, e.g.
+	// `context.ErrStopExecution`, which signals "the handler already wrote its own
+	// response, just stop" rather than "render this status/body for me".
+	noop bool
+}
+
+func (m *errorMapping) handle(ctx context.Context, err error) {
+	if m.noop {
+		return
+	}
+
+	ctx.StatusCode(m.status)
+	if m.renderer != nil {
+		m.renderer(ctx, err)
+		return
+	}
+
+	ctx.WriteString(err.Error())
+}
+
+// MapError registers a mapping between a sentinel error value and an HTTP status code,
+// so that any hero handler (or dependency) on this Party that returns an error which
+// `errors.Is` matches "err" gets "status" written to the response instead of falling
+// back to `GetErrorHandler`/`OnError`. An optional "renderer" can be given to customize
+// the response body, otherwise the error's message is written as plain text.
+//
+// Mappings are inherited by child Parties created through `Party`, and are tried in
+// registration order, closest Party first, before the parent's.
+//
+// Usage:
+//
+//	api.MapError(sql.ErrNoRows, iris.StatusNotFound)
+//
+// See `MapErrorType` and `OnError` too.
+func (api *APIContainer) MapError(err error, status int, renderer ...func(context.Context, error)) *APIContainer {
+	api.mapError(&errorMapping{
+		matches:  func(target error) bool { return errors.Is(target, err) },
+		status:   status,
+		renderer: firstRenderer(renderer),
+	})
+
+	return api
+}
+
+// MapErrorType registers a mapping between an error type and an HTTP status code,
+// using `errors.As` to test a returned error against "target" (a pointer to a type
+// implementing the `error` interface, e.g. `new(*MyError)`).
+//
+// See `MapError` and `OnError` too.
+func (api *APIContainer) MapErrorType(target interface{}, status int, renderer ...func(context.Context, error)) *APIContainer {
+	api.mapError(&errorMapping{
+		matches:  func(err error) bool { return errors.As(err, target) },
+		status:   status,
+		renderer: firstRenderer(renderer),
+	})
+
+	return api
+}
+
+func firstRenderer(renderer []func(context.Context, error)) func(context.Context, error) {
+	if len(renderer) > 0 {
+		return renderer[0]
+	}
+
+	return nil
+}
+
+func (api *APIContainer) mapError(m *errorMapping) {
+	api.errorMappings = append(api.errorMappings, m)
+	api.Container.GetErrorHandler = api.resolveErrorHandler
+}
+
+// matchErrorMapping walks this Party's own `errorMappings` and, when no match is found,
+// its parent's, all the way up to the root APIContainer, mirroring how `Container`
+// itself is inherited and cloned by children Parties. Only once that whole chain of
+// explicit `MapError`/`MapErrorType` registrations has been exhausted does it fall back
+// to the shared built-in mappings, so that a parent (or any ancestor) overriding a
+// built-in is honored by every child, instead of each child's own, unmodified copy of
+// the built-in shadowing it.
+func (api *APIContainer) matchErrorMapping(err error) *errorMapping {
+	if m := api.matchExplicitErrorMapping(err); m != nil {
+		return m
+	}
+
+	return matchBuiltinErrorMapping(err)
+}
+
+func (api *APIContainer) matchExplicitErrorMapping(err error) *errorMapping {
+	for _, m := range api.errorMappings {
+		if m.matches(err) {
+			return m
+		}
+	}
+
+	if api.parent != nil {
+		return api.parent.matchExplicitErrorMapping(err)
+	}
+
+	return nil
+}
+
+// resolveErrorHandler is installed as `Container.GetErrorHandler` as soon as `OnError`,
+// `MapError` or `MapErrorType` is called. It tries the registered error mappings first
+// and only then falls back to whatever `OnError` installed, defaulting to a bare 500.
+func (api *APIContainer) resolveErrorHandler(ctx context.Context) hero.ErrorHandler {
+	return hero.ErrorHandlerFunc(func(ctx context.Context, err error) {
+		// Stashed regardless of which path renders the response, so that an `Around`
+		// middleware's "next" function can observe it, see `errorStashKey`.
+		ctx.Values().Set(errorStashKey, err)
+
+		if m := api.matchErrorMapping(err); m != nil {
+			m.handle(ctx, err)
+			return
+		}
+
+		if api.fallbackErrorHandler != nil {
+			api.fallbackErrorHandler(ctx).HandleError(ctx, err)
+			return
+		}
+
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(err.Error())
+	})
+}
+
+// builtinErrorMappings are the default mappings every APIContainer falls back to once
+// none of its own, or any of its ancestors', explicit `MapError`/`MapErrorType`
+// registrations matched: `context.ErrStopExecution` is the "I already wrote my own
+// response, just stop the chain" signal, so its mapping is a no-op that must not
+// touch the response at all; `context.ErrNotFound` maps to `404 Not Found`, and
+// `validator.ValidationErrors` (returned by github.com/go-playground/validator) maps
+// to `400 Bad Request`.
+//
+// They're shared by every APIContainer, rather than copied into each one, precisely so
+// that a single `MapError`/`MapErrorType` call anywhere in the Party chain overrides
+// them for the whole chain, not just for the Party that happened to see the error first.
+var builtinErrorMappings = []*errorMapping{
+	{
+		matches: func(err error) bool { return errors.Is(err, context.ErrStopExecution) },
+		noop:    true,
+	},
+	{
+		matches: func(err error) bool { return errors.Is(err, context.ErrNotFound) },
+		status:  http.StatusNotFound,
+	},
+	{
+		matches: func(err error) bool {
+			var validationErrors validator.ValidationErrors
+			return errors.As(err, &validationErrors)
+		},
+		status: http.StatusBadRequest,
+	},
+}
+
+func matchBuiltinErrorMapping(err error) *errorMapping {
+	for _, m := range builtinErrorMappings {
+		if m.matches(err) {
+			return m
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/hero"
+)
+
+func TestMatchBuiltinErrorMapping(t *testing.T) {
+	if m := matchBuiltinErrorMapping(context.ErrNotFound); m == nil || m.status != http.StatusNotFound {
+		t.Fatalf("expected context.ErrNotFound to map to %d, got %+v", http.StatusNotFound, m)
+	}
+
+	if m := matchBuiltinErrorMapping(errors.New("some random error")); m != nil {
+		t.Fatalf("expected no builtin mapping for an unrelated error, got %+v", m)
+	}
+}
+
+// TestMatchBuiltinErrorMappingStopExecutionIsNoop guards against the regression
+// where the `context.ErrStopExecution` mapping unconditionally wrote a 200 status
+// and the error's own (empty) message to the response, clobbering whatever status
+// and body a handler had already written before returning it to mean "I already
+// wrote my response, now stop".
+func TestMatchBuiltinErrorMappingStopExecutionIsNoop(t *testing.T) {
+	m := matchBuiltinErrorMapping(context.ErrStopExecution)
+	if m == nil {
+		t.Fatal("expected context.ErrStopExecution to have a builtin mapping")
+	}
+
+	if !m.noop {
+		t.Fatalf("expected the context.ErrStopExecution mapping to be a no-op, got %+v", m)
+	}
+}
+
+func TestMatchErrorMappingParentOverridesBuiltinForChildren(t *testing.T) {
+	root := &APIContainer{Container: &hero.Container{}}
+	child := &APIContainer{Container: &hero.Container{}, parent: root}
+
+	// Before any override, both fall back to the shared builtin.
+	if m := child.matchErrorMapping(context.ErrNotFound); m == nil || m.status != http.StatusNotFound {
+		t.Fatalf("expected child to inherit the builtin mapping before any override, got %+v", m)
+	}
+
+	root.MapError(context.ErrNotFound, 499)
+
+	m := child.matchErrorMapping(context.ErrNotFound)
+	if m == nil || m.status != 499 {
+		t.Fatalf("expected a root-level MapError override to win for a child Party, got %+v", m)
+	}
+}
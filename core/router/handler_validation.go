@@ -0,0 +1,171 @@
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/hero"
+)
+
+// HandlerValidationMode describes how an `APIContainer` should react when one of
+// its registered hero handlers cannot be satisfied by the Party's `Container.Dependencies`.
+//
+// See `APIContainer.SetHandlerValidation`.
+type HandlerValidationMode uint32
+
+const (
+	// ValidationOff disables the startup-time handler validation (the default).
+	// Unresolvable parameters are only discovered at request time, through the
+	// generic reflect-based error already returned by the hero Container,
+	// exactly as it behaved before this feature existed.
+	ValidationOff HandlerValidationMode = iota
+	// ValidationWarn runs the same checks as ValidationStrict but, instead of panicking,
+	// logs the resulting errors through the Party's logger and lets the server start anyway.
+	ValidationWarn
+	// ValidationStrict panics, at registration time, if any handler registered through this
+	// APIContainer has a parameter that cannot be resolved, naming the exact handler,
+	// file, line and parameter responsible.
+	ValidationStrict
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// pathParamTypes are the Go types a hero handler may accept to receive a path parameter,
+// mirroring the types the `macro` package itself is able to parse from the request path.
+var pathParamTypes = []reflect.Kind{
+	reflect.String,
+	reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+	reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+	reflect.Bool, reflect.Float32, reflect.Float64,
+}
+
+// HandlerValidationError is returned by the startup-time handler validation performed
+// when an `APIContainer`'s `HandlerValidationMode` is not `ValidationOff`.
+// It names the exact input parameter of a hero handler that could not be resolved
+// by the Party's registered dependencies, together with the handler's source location
+// and, when one exists, the closest registered dependency.
+type HandlerValidationError struct {
+	Path       string
+	Handler    *runtime.Func
+	ParamIndex int
+	ParamType  reflect.Type
+	Closest    reflect.Type
+}
+
+// Error completes the `error` interface.
+func (e *HandlerValidationError) Error() string {
+	file, line := "<unknown>", 0
+	if e.Handler != nil {
+		file, line = e.Handler.FileLine(e.Handler.Entry())
+	}
+
+	msg := fmt.Sprintf("iris: %s:%d: handler registered for %q has a parameter at index %d of type %s that cannot be resolved by any registered dependency",
+		file, line, e.Path, e.ParamIndex, e.ParamType)
+
+	if e.Closest != nil {
+		msg += fmt.Sprintf(" (closest registered dependency is of type %s, did you mean to accept that one instead?)", e.Closest)
+	}
+
+	return msg
+}
+
+// preCheckHandler walks the reflect.Type of a hero handler and verifies, before the
+// server ever starts accepting requests, that every input parameter can be satisfied
+// either by a path parameter, a `context.Context`, a dependency already registered
+// on this Party's Container or, for a struct (or pointer-to-struct) parameter, by
+// binding the request body. It returns a descriptive `*HandlerValidationError`,
+// or nil if the handler looks sound.
+//
+// Handlers that are not plain functions (e.g. already a `context.Handler` or a
+// controller value) are not backed by a single reflect signature and are left
+// for the existing, request-time validation to catch.
+func (api *APIContainer) preCheckHandler(fn interface{}, fullpath string, paramsCount int) error {
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func {
+		return nil
+	}
+
+	deps := api.Container.Dependencies
+	paramsSeen := 0
+
+	for i, n := 0, typ.NumIn(); i < n; i++ {
+		in := typ.In(i)
+
+		if in == contextType {
+			continue
+		}
+
+		if isPathParamType(in) && paramsSeen < paramsCount {
+			paramsSeen++
+			continue
+		}
+
+		if matchDependency(deps, in) != nil {
+			continue
+		}
+
+		// A struct (or pointer-to-struct) parameter that isn't a path parameter and
+		// doesn't match any registered dependency is exactly the shape `Container`
+		// resolves at request time by binding it from the request body, the same
+		// implicit "hero" convention `describeRoute` relies on to build the OpenAPI
+		// requestBody. It's not an unresolved parameter, so it isn't reported here.
+		if isStructSchema(in) {
+			continue
+		}
+
+		return &HandlerValidationError{
+			Path:       fullpath,
+			Handler:    runtime.FuncForPC(reflect.ValueOf(fn).Pointer()),
+			ParamIndex: i,
+			ParamType:  in,
+			Closest:    closestDependency(deps, in),
+		}
+	}
+
+	return nil
+}
+
+func isPathParamType(typ reflect.Type) bool {
+	for _, k := range pathParamTypes {
+		if typ.Kind() == k {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchDependency reports the registered dependency, if any, that can resolve "typ".
+func matchDependency(deps []*hero.Dependency, typ reflect.Type) *hero.Dependency {
+	for _, dep := range deps {
+		if dep.Type == typ {
+			return dep
+		}
+
+		if typ.Kind() == reflect.Interface && dep.Type.Implements(typ) {
+			return dep
+		}
+
+		if dep.Type.AssignableTo(typ) {
+			return dep
+		}
+	}
+
+	return nil
+}
+
+// closestDependency is a best-effort suggestion used by `HandlerValidationError`,
+// it returns the first registered dependency that shares the unresolved
+// parameter's reflect.Kind, e.g. to point a `*UserService` typo towards
+// an already registered `*UserRepository`.
+func closestDependency(deps []*hero.Dependency, typ reflect.Type) reflect.Type {
+	for _, dep := range deps {
+		if dep.Type.Kind() == typ.Kind() {
+			return dep.Type
+		}
+	}
+
+	return nil
+}
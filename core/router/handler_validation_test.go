@@ -0,0 +1,119 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kataras/iris/v12/hero"
+)
+
+type fakeUserService struct{}
+type fakeOrderService struct{}
+
+// fakeLogger is an interface no registered dependency in these tests implements,
+// used where a test needs a parameter that's genuinely unresolvable (as opposed to
+// an implicit request-body struct, which `preCheckHandler` must not flag).
+type fakeLogger interface {
+	Log(string)
+}
+
+type fakeCreateUserRequest struct {
+	Name string `json:"name"`
+}
+
+func TestIsPathParamType(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		want bool
+	}{
+		{reflect.TypeOf(""), true},
+		{reflect.TypeOf(uint64(0)), true},
+		{reflect.TypeOf(true), true},
+		{reflect.TypeOf(fakeUserService{}), false},
+		{reflect.TypeOf(&fakeUserService{}), false},
+	}
+
+	for _, tt := range tests {
+		if got := isPathParamType(tt.typ); got != tt.want {
+			t.Errorf("isPathParamType(%s) = %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestMatchDependency(t *testing.T) {
+	deps := []*hero.Dependency{{Type: reflect.TypeOf(&fakeUserService{})}}
+
+	if dep := matchDependency(deps, reflect.TypeOf(&fakeUserService{})); dep == nil {
+		t.Fatal("expected an exact type match to resolve")
+	}
+
+	if dep := matchDependency(deps, reflect.TypeOf(&fakeOrderService{})); dep != nil {
+		t.Fatal("expected no dependency to resolve an unrelated type")
+	}
+}
+
+func TestClosestDependencySuggestsSameKind(t *testing.T) {
+	deps := []*hero.Dependency{{Type: reflect.TypeOf(&fakeUserService{})}}
+
+	closest := closestDependency(deps, reflect.TypeOf(&fakeOrderService{}))
+	if closest == nil {
+		t.Fatal("expected a closest-dependency suggestion for a pointer-to-struct parameter")
+	}
+}
+
+func TestPreCheckHandlerIgnoresNonFuncHandlers(t *testing.T) {
+	api := &APIContainer{Container: &hero.Container{}}
+
+	if err := api.preCheckHandler(struct{}{}, "/", 0); err != nil {
+		t.Fatalf("expected handlers that are not plain funcs to be left to request-time validation, got %v", err)
+	}
+}
+
+func TestPreCheckHandlerReportsUnresolvedParameter(t *testing.T) {
+	api := &APIContainer{Container: &hero.Container{
+		Dependencies: []*hero.Dependency{{Type: reflect.TypeOf(&fakeUserService{})}},
+	}}
+
+	// missing is an interface type, not a struct/pointer-to-struct, so it can't be
+	// mistaken for an implicit request-body bind: it's genuinely unresolvable.
+	handler := func(svc *fakeUserService, missing fakeLogger) {}
+
+	err := api.preCheckHandler(handler, "/orders", 0)
+	if err == nil {
+		t.Fatal("expected an error for a parameter with no matching registered dependency")
+	}
+
+	validationErr, ok := err.(*HandlerValidationError)
+	if !ok {
+		t.Fatalf("expected a *HandlerValidationError, got %T", err)
+	}
+
+	if validationErr.ParamIndex != 1 || validationErr.ParamType != reflect.TypeOf((*fakeLogger)(nil)).Elem() {
+		t.Fatalf("unexpected validation error details: %+v", validationErr)
+	}
+}
+
+// TestPreCheckHandlerAllowsImplicitBodyBindStruct guards against the false-positive
+// regression where every handler binding its input from the request body - the most
+// common hero handler shape there is - was rejected (ValidationStrict) or warned
+// about (ValidationWarn) at registration, because an unmatched struct
+// parameter used to be indistinguishable from a genuinely unresolved dependency.
+func TestPreCheckHandlerAllowsImplicitBodyBindStruct(t *testing.T) {
+	api := &APIContainer{Container: &hero.Container{
+		Dependencies: []*hero.Dependency{{Type: reflect.TypeOf(&fakeUserService{})}},
+	}}
+
+	handler := func(svc *fakeUserService, input fakeCreateUserRequest) (fakeCreateUserRequest, error) {
+		return input, nil
+	}
+
+	if err := api.preCheckHandler(handler, "/users", 0); err != nil {
+		t.Fatalf("expected an unmatched struct parameter to be treated as an implicit request body bind, got %v", err)
+	}
+
+	// A pointer-to-struct must be accepted the same way.
+	ptrHandler := func(input *fakeCreateUserRequest) error { return nil }
+	if err := api.preCheckHandler(ptrHandler, "/users", 0); err != nil {
+		t.Fatalf("expected an unmatched *struct parameter to be treated as an implicit request body bind, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package router
+
+import (
+	stdcontext "context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/kataras/iris/v12/context"
+)
+
+type mountedContextKeyType struct{}
+
+// mountedContextKey is the stdlib `*http.Request` context key `Mount` stashes the live
+// Iris `context.Context` under, retrieved back through `FromRequest`.
+var mountedContextKey mountedContextKeyType
+
+// Mount registers "h" to handle any request under "prefix" ("/prefix/..."), translating
+// the Iris `context.Context` into a standard `http.ResponseWriter`/`*http.Request` pair
+// and stripping "prefix" from the request's URL path first, same as `http.StripPrefix`
+// would. This lets a legacy `http.Handler`-based subsystem - a `chi.Router`,
+// `gorilla/mux`, or a plain `net/http.ServeMux` - be adopted incrementally into an Iris
+// application without rewriting every one of its handlers as a hero handler up front.
+//
+// Dependencies already resolved for the request by this Party's Container (through
+// `Use`/`Around` middleware that ran before the mount point) can be retrieved from
+// within "h", or anything it calls into, with `router.FromRequest(r).Get`. Only values
+// of dependencies registered through `RegisterScoped` are retrievable that way; other
+// dependencies are not cached per request and `Get` reports false for them.
+//
+// Mount is registered for every HTTP method; the returned *Route is the one registered
+// for GET.
+//
+// See `MountFunc` too.
+func (api *APIContainer) Mount(prefix string, h http.Handler) *Route {
+	api.flushMiddleware()
+	handler := mountHandler(prefix, h)
+	fullpath := prefix + "/{path:path}"
+
+	var route *Route
+	for _, m := range AllMethods {
+		for _, r := range api.Self.HandleMany(m, fullpath, handler) {
+			if m == http.MethodGet {
+				route = r
+			}
+		}
+	}
+
+	return route
+}
+
+// MountFunc is the `http.HandlerFunc` equivalent of `Mount`.
+func (api *APIContainer) MountFunc(prefix string, h http.HandlerFunc) *Route {
+	return api.Mount(prefix, h)
+}
+
+func mountHandler(prefix string, h http.Handler) context.Handler {
+	return func(ctx context.Context) {
+		r := ctx.Request()
+		w := ctx.ResponseWriter()
+
+		r2 := r.WithContext(stdcontext.WithValue(r.Context(), mountedContextKey, ctx))
+
+		// r.WithContext shallow-copies the *http.Request, so r2.URL still points at the
+		// same *url.URL as r.URL - clone it before mutating, otherwise the rewritten path
+		// leaks back into the original request and corrupts it for whatever runs after
+		// the mounted handler returns (Done handlers, access logs, ctx.Path()).
+		u := *r.URL
+		u.Path = stripMountPrefix(prefix, u.Path)
+		if u.RawPath != "" {
+			// Keep RawPath in sync with Path, same as http.StripPrefix does, so routers
+			// that prefer EscapedPath() for percent-encoded segments see a consistent URL.
+			u.RawPath = stripMountPrefix(prefix, u.RawPath)
+		}
+		r2.URL = &u
+
+		h.ServeHTTP(w, r2)
+	}
+}
+
+func stripMountPrefix(prefix, path string) string {
+	if p := strings.TrimPrefix(path, prefix); p != path {
+		if p == "" {
+			return "/"
+		}
+
+		return p
+	}
+
+	return path
+}
+
+// FromRequest returns an accessor over whatever dependencies were stashed on "r" by
+// `Mount`/`MountFunc`, letting code that lives outside of Iris' own DI container (a
+// mounted `chi.Router`'s handlers, for example) still retrieve values resolved by this
+// Party's `Container` for the current request.
+func FromRequest(r *http.Request) MountedRequest {
+	ctx, _ := r.Context().Value(mountedContextKey).(context.Context)
+	return MountedRequest{ctx: ctx}
+}
+
+// MountedRequest is returned by `FromRequest`.
+type MountedRequest struct {
+	ctx context.Context
+}
+
+// Context returns the live Iris `context.Context` the mounted request originated from,
+// or nil if "r" was not served through `Mount`/`MountFunc`.
+func (m MountedRequest) Context() context.Context {
+	return m.ctx
+}
+
+// Get fills "outPtr" (a pointer to the dependency's type) with the value resolved for
+// that type during the current request, and reports whether a value was found. Only
+// values cached by a dependency registered through `RegisterScoped` are found.
+func (m MountedRequest) Get(outPtr interface{}) bool {
+	if m.ctx == nil {
+		return false
+	}
+
+	typ := reflect.TypeOf(outPtr)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		panic("iris: MountedRequest.Get: outPtr must be a pointer")
+	}
+
+	v := m.ctx.Values().Get(scopedValueKey(typ.Elem()))
+	if v == nil {
+		return false
+	}
+
+	reflect.ValueOf(outPtr).Elem().Set(reflect.ValueOf(v))
+	return true
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestStripMountPrefix(t *testing.T) {
+	tests := []struct {
+		prefix, path, want string
+	}{
+		{"/legacy", "/legacy/users/1", "/users/1"},
+		{"/legacy", "/legacy", "/"},
+		{"/legacy", "/other", "/other"},
+	}
+
+	for _, tt := range tests {
+		if got := stripMountPrefix(tt.prefix, tt.path); got != tt.want {
+			t.Errorf("stripMountPrefix(%q, %q) = %q, want %q", tt.prefix, tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestStripMountPrefixOnURLCopyLeavesOriginalUntouched checks the clone-then-rewrite
+// step `mountHandler` uses against the r2.URL aliasing bug: cloning *r.URL before
+// rewriting its Path/RawPath must leave the original *url.URL (and therefore the
+// original *http.Request seen by the rest of the request's lifecycle) untouched.
+func TestStripMountPrefixOnURLCopyLeavesOriginalUntouched(t *testing.T) {
+	original := &url.URL{Path: "/legacy/users/1", RawPath: "/legacy/users/1"}
+
+	u := *original
+	u.Path = stripMountPrefix("/legacy", u.Path)
+	u.RawPath = stripMountPrefix("/legacy", u.RawPath)
+
+	if original.Path != "/legacy/users/1" || original.RawPath != "/legacy/users/1" {
+		t.Fatalf("expected the original URL to be left untouched, got %+v", original)
+	}
+
+	if u.Path != "/users/1" || u.RawPath != "/users/1" {
+		t.Fatalf("expected the cloned URL to be rewritten, got %+v", u)
+	}
+}
@@ -0,0 +1,106 @@
+// Package openapi holds a minimal, in-memory representation of an OpenAPI 3.1
+// document, populated by `router.APIContainer.OpenAPI` purely from the reflect
+// signatures of the routes registered on a DI-featured Party - no annotations required.
+package openapi
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the root OpenAPI 3.1 document.
+type Spec struct {
+	OpenAPI string              `json:"openapi" yaml:"openapi"`
+	Info    Info                `json:"info" yaml:"info"`
+	Paths   map[string]PathItem `json:"paths" yaml:"paths"`
+}
+
+// Info is the OpenAPI "info" object.
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// PathItem groups the Operations of a single path by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation describes a single method of a path.
+type Operation struct {
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// Parameter describes a single path (or query) parameter.
+type Parameter struct {
+	Name     string `json:"name" yaml:"name"`
+	In       string `json:"in" yaml:"in"`
+	Required bool   `json:"required" yaml:"required"`
+	Schema   Schema `json:"schema" yaml:"schema"`
+}
+
+// RequestBody describes the body expected by an Operation.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType pairs a content-type with the Schema of its body.
+type MediaType struct {
+	Schema Schema `json:"schema" yaml:"schema"`
+}
+
+// Response describes a single status code's response.
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Schema is a (deliberately partial) JSON Schema, just enough to describe the
+// struct types flowing in and out of hero handlers.
+type Schema struct {
+	Type       string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Format     string            `json:"format,omitempty" yaml:"format,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Items      *Schema           `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// New returns an empty Spec for the given title and version, ready to be filled in.
+func New(title, version string) *Spec {
+	return &Spec{
+		OpenAPI: "3.1.0",
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+	}
+}
+
+// Operation returns (creating it if necessary) the Operation for "method" on "path".
+func (s *Spec) Operation(path, method string) *Operation {
+	item, ok := s.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		s.Paths[path] = item
+	}
+
+	op, ok := item[method]
+	if !ok {
+		op = &Operation{Responses: make(map[string]Response)}
+		item[method] = op
+	}
+
+	return op
+}
+
+// JSON marshals the Spec as indented JSON.
+func (s *Spec) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// YAML marshals the Spec as YAML.
+func (s *Spec) YAML() ([]byte, error) {
+	return yaml.Marshal(s)
+}
@@ -0,0 +1,315 @@
+package router
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/core/router/openapi"
+)
+
+// routeRegistration remembers enough about a registered *Route to describe it in an
+// OpenAPI document without the end-developer having to annotate anything: the reflect.Type
+// of its (last, canonical) handler and whatever `Describe` options were attached to it.
+type routeRegistration struct {
+	route       *Route
+	handlerType reflect.Type
+	options     []DescribeOption
+}
+
+// DescribeOption customizes the `openapi.Operation` generated for a single *Route.
+// See `APIContainer.Describe`.
+type DescribeOption func(*openapi.Operation)
+
+// Summary overrides the one-line OpenAPI summary of a route.
+func Summary(summary string) DescribeOption {
+	return func(op *openapi.Operation) { op.Summary = summary }
+}
+
+// Description overrides the long-form OpenAPI description of a route.
+func Description(description string) DescribeOption {
+	return func(op *openapi.Operation) { op.Description = description }
+}
+
+// Tags overrides the OpenAPI tags of a route.
+func Tags(tags ...string) DescribeOption {
+	return func(op *openapi.Operation) { op.Tags = tags }
+}
+
+// Describe attaches one or more `DescribeOption`s to a previously registered *Route,
+// overriding the summary, description or tags that `OpenAPI` would otherwise
+// leave empty or infer on its own.
+func (api *APIContainer) Describe(route *Route, opts ...DescribeOption) *APIContainer {
+	for _, r := range api.routes {
+		if r.route == route {
+			r.options = append(r.options, opts...)
+			break
+		}
+	}
+
+	return api
+}
+
+// SetOpenAPIInfo sets the "title" and "version" fields `OpenAPI` fills the
+// generated document's "info" object with. Defaults to "API" and "0.0.1".
+func (api *APIContainer) SetOpenAPIInfo(title, version string) *APIContainer {
+	api.openAPITitle, api.openAPIVersion = title, version
+	return api
+}
+
+// trackRoute remembers "route" and the reflect.Type of its canonical (last) handler,
+// so `OpenAPI` can later describe it without needing the original "handlersFn" again.
+func (api *APIContainer) trackRoute(route *Route, handlersFn ...interface{}) {
+	if route == nil || len(handlersFn) == 0 {
+		return
+	}
+
+	typ := reflect.TypeOf(handlersFn[len(handlersFn)-1])
+	if typ == nil || typ.Kind() != reflect.Func {
+		return
+	}
+
+	api.routes = append(api.routes, &routeRegistration{route: route, handlerType: typ})
+}
+
+// OpenAPI builds an OpenAPI 3.1 document out of every route registered through this
+// APIContainer and every child Party created from it through `Party`, recursively, using
+// only the reflect signature each handler was registered with: path-param types are
+// resolved through the `macro` syntax embedded in the route's path, struct dependencies
+// bound from the request body become the `requestBody` schema, and the handler's
+// returned struct becomes its "200" response. Errors (including the ones mapped through
+// `MapError`/`MapErrorType`) become the operation's "default" response.
+//
+// See `ServeOpenAPI` and `Describe` too.
+func (api *APIContainer) OpenAPI() *openapi.Spec {
+	title, version := api.openAPITitle, api.openAPIVersion
+	if title == "" {
+		title = "API"
+	}
+	if version == "" {
+		version = "0.0.1"
+	}
+
+	spec := openapi.New(title, version)
+	api.collectOpenAPI(spec)
+	return spec
+}
+
+// collectOpenAPI describes this APIContainer's own routes into "spec", then recurses
+// into every child Party registered through `Party`, so that `OpenAPI` called on the
+// root of an application covers routes nested arbitrarily deep under child Parties.
+func (api *APIContainer) collectOpenAPI(spec *openapi.Spec) {
+	for _, r := range api.routes {
+		api.describeRoute(spec, r)
+	}
+
+	for _, child := range api.children {
+		child.collectOpenAPI(spec)
+	}
+}
+
+func (api *APIContainer) describeRoute(spec *openapi.Spec, r *routeRegistration) {
+	path := openAPIPath(r.route.Path)
+	op := spec.Operation(path, strings.ToLower(r.route.Method))
+	op.Responses["default"] = openapi.Response{Description: "An error occurred"}
+
+	typ := r.handlerType
+	pathParams := openAPIPathParam.FindAllStringSubmatch(r.route.Path, -1)
+	paramsSeen := 0
+
+	for i, n := 0, typ.NumIn(); i < n; i++ {
+		in := typ.In(i)
+		if in == contextType {
+			continue
+		}
+
+		// Mirrors `preCheckHandler`'s own decision order: a parameter is only treated as
+		// a path parameter, instead of a DI dependency, when its type matches one of the
+		// macro types and there's still an undescribed path parameter left to assign it to.
+		if isPathParamType(in) && paramsSeen < len(pathParams) {
+			if name, schema, ok := pathParamSchema(pathParams, paramsSeen); ok {
+				op.Parameters = append(op.Parameters, openapi.Parameter{
+					Name: name, In: "path", Required: true, Schema: schema,
+				})
+			}
+
+			paramsSeen++
+			continue
+		}
+
+		// Mirrors `preCheckHandler`'s own implicit body-bind convention: a struct (or
+		// pointer-to-struct) parameter is request body, not a dependency, precisely
+		// when it does NOT match anything registered on this Party's Container.
+		if matchDependency(api.Container.Dependencies, in) == nil && isStructSchema(in) {
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content: map[string]openapi.MediaType{
+					"application/json": {Schema: structSchema(in)},
+				},
+			}
+		}
+	}
+
+	for i, n := 0, typ.NumOut(); i < n; i++ {
+		out := typ.Out(i)
+		if out == errType {
+			continue
+		}
+
+		if isStructSchema(out) {
+			op.Responses["200"] = openapi.Response{
+				Description: "OK",
+				Content: map[string]openapi.MediaType{
+					"application/json": {Schema: structSchema(out)},
+				},
+			}
+		}
+	}
+
+	for _, opt := range r.options {
+		opt(op)
+	}
+}
+
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+
+// openAPIPathParam matches Iris' macro path-parameter syntax, e.g. "{id:uint64}".
+var openAPIPathParam = regexp.MustCompile(`\{(\w+)(?::(\w+))?[^}]*\}`)
+
+// openAPIPath rewrites an Iris route path ("/users/{id:uint64}") into the
+// OpenAPI path-template syntax ("/users/{id}").
+func openAPIPath(path string) string {
+	return openAPIPathParam.ReplaceAllString(path, "{$1}")
+}
+
+// pathParamSchema reports whether "index" (a count of path parameters seen so far in the
+// handler's signature, not its raw parameter index) falls within "matches" - the route
+// path's own regex-matched path parameters - returning its name and a Schema derived
+// from its macro type.
+func pathParamSchema(matches [][]string, index int) (string, openapi.Schema, bool) {
+	if index < 0 || index >= len(matches) {
+		return "", openapi.Schema{}, false
+	}
+
+	m := matches[index]
+	name, macroType := m[1], m[2]
+
+	switch macroType {
+	case "uint64", "uint32", "uint8", "uint", "int64", "int32", "int8", "int", "number":
+		return name, openapi.Schema{Type: "integer", Format: macroType}, true
+	case "bool", "boolean":
+		return name, openapi.Schema{Type: "boolean"}, true
+	case "uuid":
+		return name, openapi.Schema{Type: "string", Format: "uuid"}, true
+	default:
+		return name, openapi.Schema{Type: "string"}, true
+	}
+}
+
+func isStructSchema(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind() == reflect.Struct
+}
+
+// structSchema builds a (shallow) object Schema out of a struct's exported fields,
+// using their "json" tag for property names, same as `encoding/json` itself would.
+func structSchema(typ reflect.Type) openapi.Schema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	schema := openapi.Schema{Type: "object", Properties: make(map[string]openapi.Schema)}
+
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" { // unexported.
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tagName := strings.Split(tag, ",")[0]; tagName == "-" {
+				continue
+			} else if tagName != "" {
+				name = tagName
+			}
+		}
+
+		schema.Properties[name] = fieldSchema(field.Type)
+	}
+
+	return schema
+}
+
+func fieldSchema(typ reflect.Type) openapi.Schema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		return structSchema(typ)
+	case reflect.Slice, reflect.Array:
+		item := fieldSchema(typ.Elem())
+		return openapi.Schema{Type: "array", Items: &item}
+	case reflect.String:
+		return openapi.Schema{Type: "string"}
+	case reflect.Bool:
+		return openapi.Schema{Type: "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return openapi.Schema{Type: "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi.Schema{Type: "integer"}
+	default:
+		return openapi.Schema{}
+	}
+}
+
+// ServeOpenAPI registers a route at "path" that serves the document returned by
+// `OpenAPI` as JSON, plus "path"+".yaml" for YAML and "path"+"/ui" for a RapiDoc page.
+func (api *APIContainer) ServeOpenAPI(path string) {
+	api.Self.Get(path, func(ctx context.Context) {
+		spec := api.OpenAPI()
+		b, err := spec.JSON()
+		if err != nil {
+			ctx.StopWithError(500, err)
+			return
+		}
+
+		ctx.ContentType("application/json")
+		ctx.Write(b)
+	})
+
+	api.Self.Get(path+".yaml", func(ctx context.Context) {
+		spec := api.OpenAPI()
+		b, err := spec.YAML()
+		if err != nil {
+			ctx.StopWithError(500, err)
+			return
+		}
+
+		ctx.ContentType("application/yaml")
+		ctx.Write(b)
+	})
+
+	api.Self.Get(path+"/ui", func(ctx context.Context) {
+		ctx.ContentType("text/html")
+		ctx.WriteString(swaggerUIHTML(path))
+	})
+}
+
+func swaggerUIHTML(specPath string) string {
+	return `<!DOCTYPE html>
+<html>
+<head><title>API Reference</title></head>
+<body>
+<rapi-doc spec-url="` + specPath + `"></rapi-doc>
+<script type="module" src="https://unpkg.com/rapidoc/dist/rapidoc-min.js"></script>
+</body>
+</html>`
+}
@@ -0,0 +1,103 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kataras/iris/v12/core/router/openapi"
+	"github.com/kataras/iris/v12/hero"
+)
+
+type userServiceDep struct{}
+
+func TestDescribeRouteMixingDependencyAndPathParam(t *testing.T) {
+	api := &APIContainer{
+		Container: &hero.Container{
+			Dependencies: []*hero.Dependency{{Type: reflect.TypeOf(&userServiceDep{})}},
+		},
+	}
+
+	// svc is a DI dependency, id is the route's only path parameter: the dependency
+	// must not be mistaken for it, and id must still be described.
+	handler := func(svc *userServiceDep, id uint64) {}
+
+	r := &routeRegistration{
+		route:       &Route{Path: "/users/{id:uint64}", Method: "GET"},
+		handlerType: reflect.TypeOf(handler),
+	}
+
+	spec := openapi.New("Test", "0.0.1")
+	api.describeRoute(spec, r)
+
+	op := spec.Paths["/users/{id}"]["get"]
+	if len(op.Parameters) != 1 {
+		t.Fatalf("expected exactly one path parameter to be described, got %+v", op.Parameters)
+	}
+
+	if op.Parameters[0].Name != "id" {
+		t.Fatalf("expected the path parameter to be %q, got %q", "id", op.Parameters[0].Name)
+	}
+}
+
+// TestDescribeRouteBodyIsTheUnmatchedStructParam guards against the inverted
+// condition bug: the struct parameter that matches a registered dependency is a
+// resolved service, not wire data, and must not become the requestBody; the
+// unmatched one, which `Container` binds from the request body at request time,
+// must.
+func TestDescribeRouteBodyIsTheUnmatchedStructParam(t *testing.T) {
+	api := &APIContainer{
+		Container: &hero.Container{
+			Dependencies: []*hero.Dependency{{Type: reflect.TypeOf(&userServiceDep{})}},
+		},
+	}
+
+	type createUserRequest struct {
+		Name string `json:"name"`
+	}
+
+	handler := func(svc *userServiceDep, input createUserRequest) {}
+
+	r := &routeRegistration{
+		route:       &Route{Path: "/users", Method: "POST"},
+		handlerType: reflect.TypeOf(handler),
+	}
+
+	spec := openapi.New("Test", "0.0.1")
+	api.describeRoute(spec, r)
+
+	op := spec.Paths["/users"]["post"]
+	if op.RequestBody == nil {
+		t.Fatal("expected the unmatched struct parameter to produce a requestBody")
+	}
+
+	schema := op.RequestBody.Content["application/json"].Schema
+	if _, ok := schema.Properties["name"]; !ok {
+		t.Fatalf("expected the requestBody schema to describe createUserRequest, got %+v", schema)
+	}
+}
+
+func TestOpenAPICollectsChildPartyRoutes(t *testing.T) {
+	root := &APIContainer{Container: &hero.Container{}}
+	child := &APIContainer{Container: &hero.Container{}}
+	root.children = append(root.children, child)
+
+	handler := func() {}
+	root.routes = append(root.routes, &routeRegistration{
+		route:       &Route{Path: "/root", Method: "GET"},
+		handlerType: reflect.TypeOf(handler),
+	})
+	child.routes = append(child.routes, &routeRegistration{
+		route:       &Route{Path: "/child", Method: "GET"},
+		handlerType: reflect.TypeOf(handler),
+	})
+
+	spec := root.OpenAPI()
+
+	if _, ok := spec.Paths["/root"]; !ok {
+		t.Fatal("expected the root Party's own route to be described")
+	}
+
+	if _, ok := spec.Paths["/child"]; !ok {
+		t.Fatal("expected a child Party's route to be described too")
+	}
+}
@@ -0,0 +1,144 @@
+package router
+
+import (
+	"reflect"
+
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/hero"
+)
+
+// scopedValueKeyPrefix namespaces the `context.Context` Values keys a scoped
+// dependency's cached value (and its finalizer lookup) is stored under.
+const scopedValueKeyPrefix = "iris.router.scoped."
+
+func scopedValueKey(typ reflect.Type) string {
+	return scopedValueKeyPrefix + typ.String()
+}
+
+// ScopedDependency is returned by `RegisterScoped`; besides being a regular
+// `*hero.Dependency` it also lets a `Finalizer` be attached to it.
+type ScopedDependency struct {
+	*hero.Dependency
+
+	api     *APIContainer
+	outType reflect.Type
+}
+
+// Finalizer registers "fn" to run once, automatically, after the request that
+// resolved this scoped dependency finishes, through a `Done` handler installed
+// the first time any scoped dependency is registered. "fn" must accept the
+// dependency's resolved type as its only parameter, e.g.:
+//
+//	api.RegisterScoped(newTx).Finalizer(func(tx *sql.Tx) { tx.Commit() })
+func (d *ScopedDependency) Finalizer(fn interface{}) *ScopedDependency {
+	d.api.scopedFinalizers = append(d.api.scopedFinalizers, scopedFinalizer{
+		outType: d.outType,
+		fn:      reflect.ValueOf(fn),
+	})
+
+	return d
+}
+
+type scopedFinalizer struct {
+	outType reflect.Type
+	fn      reflect.Value
+}
+
+// RegisterScoped registers a dependency that is resolved at most once per request:
+// the first handler (or other dependency) on this Party that needs it invokes "fn",
+// every other one within the same request receives the exact same value back.
+// Unlike `RegisterDependency`, "fn" must accept `context.Context` as its first
+// parameter, since that's what the per-request cache is keyed on:
+//
+//	func(ctx iris.Context) *sql.Tx
+//	func(ctx iris.Context) (*sql.Tx, error)
+//
+// See `RegisterSingleton`, `RegisterTransient` and `(*ScopedDependency).Finalizer` too.
+func (api *APIContainer) RegisterScoped(fn interface{}) *ScopedDependency {
+	typ := reflect.TypeOf(fn)
+	if typ == nil || typ.Kind() != reflect.Func || typ.NumIn() == 0 || typ.In(0) != contextType {
+		panic("iris: RegisterScoped: fn must be a func(context.Context, ...) accepting the Context as its first parameter")
+	}
+
+	outType := typ.Out(0)
+	fnValue := reflect.ValueOf(fn)
+
+	wrapped := reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		ctx := args[0].Interface().(context.Context)
+		key := scopedValueKey(outType)
+
+		if v := ctx.Values().Get(key); v != nil {
+			return scopedResults(typ, v)
+		}
+
+		results := fnValue.Call(args)
+		if shouldCacheScopedResult(typ, results) {
+			ctx.Values().Set(key, results[0].Interface())
+		}
+
+		return results
+	}).Interface()
+
+	dep := api.Container.Register(wrapped)
+	api.ensureScopedFinalizerHook()
+
+	return &ScopedDependency{Dependency: dep, api: api, outType: outType}
+}
+
+// shouldCacheScopedResult reports whether "fn"'s results are worth caching for the
+// rest of the request: a nil "error" return is, a non-nil one isn't. Caching a
+// zero/typed-nil value on error would let every later handler within the same
+// request see it as a cache hit - masking the error - and hand a nil value (e.g. a
+// nil *sql.Tx) to whatever relies on it, including a `Finalizer` that calls
+// something like Commit().
+func shouldCacheScopedResult(typ reflect.Type, results []reflect.Value) bool {
+	return typ.NumOut() < 2 || results[1].IsNil()
+}
+
+func scopedResults(typ reflect.Type, cached interface{}) []reflect.Value {
+	out := make([]reflect.Value, typ.NumOut())
+	out[0] = reflect.ValueOf(cached)
+	for i := 1; i < typ.NumOut(); i++ {
+		out[i] = reflect.Zero(typ.Out(i))
+	}
+
+	return out
+}
+
+// RegisterSingleton registers a dependency that is resolved once, at registration
+// time, and shared by every request, same as `RegisterDependency` already does for
+// a plain struct value - "v" should not be a func that needs per-request state.
+//
+// See `RegisterScoped` and `RegisterTransient` too.
+func (api *APIContainer) RegisterSingleton(v interface{}) *hero.Dependency {
+	return api.Container.Register(v)
+}
+
+// RegisterTransient registers a dependency that is resolved anew on every single
+// handler (or other dependency) that accepts it, same as `RegisterDependency`
+// already does for a plain func - it's named explicitly so call sites can
+// document their intended lifetime next to `RegisterScoped` and `RegisterSingleton`.
+func (api *APIContainer) RegisterTransient(fn interface{}) *hero.Dependency {
+	return api.Container.Register(fn)
+}
+
+// ensureScopedFinalizerHook installs, once, a `Done` handler that runs every
+// registered `scopedFinalizer` whose dependency was actually resolved during
+// the just-finished request.
+func (api *APIContainer) ensureScopedFinalizerHook() {
+	if api.scopedFinalizerHookInstalled {
+		return
+	}
+	api.scopedFinalizerHookInstalled = true
+
+	api.Done(func(ctx context.Context) {
+		for _, f := range api.scopedFinalizers {
+			v := ctx.Values().Get(scopedValueKey(f.outType))
+			if v == nil {
+				continue
+			}
+
+			f.fn.Call([]reflect.Value{reflect.ValueOf(v)})
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestScopedResultsFillsCachedValueAndZeroError(t *testing.T) {
+	fnType := reflect.TypeOf(func() (string, error) { return "", nil })
+
+	out := scopedResults(fnType, "cached-value")
+	if len(out) != 2 {
+		t.Fatalf("expected 2 return values, got %d", len(out))
+	}
+
+	if got := out[0].Interface().(string); got != "cached-value" {
+		t.Fatalf("expected the cached value to be reused, got %q", got)
+	}
+
+	if err, _ := out[1].Interface().(error); err != nil {
+		t.Fatalf("expected a nil error on a cache hit, got %v", err)
+	}
+}
+
+func TestScopedResultsSingleReturnValue(t *testing.T) {
+	fnType := reflect.TypeOf(func() int { return 0 })
+
+	out := scopedResults(fnType, 42)
+	if len(out) != 1 || out[0].Interface().(int) != 42 {
+		t.Fatalf("expected a single cached return value of 42, got %+v", out)
+	}
+}
+
+func TestShouldCacheScopedResult(t *testing.T) {
+	okType := reflect.TypeOf(func() (string, error) { return "", nil })
+
+	okResults := []reflect.Value{reflect.ValueOf("tx"), reflect.Zero(reflect.TypeOf((*error)(nil)).Elem())}
+	if !shouldCacheScopedResult(okType, okResults) {
+		t.Fatal("expected a nil error result to be cached")
+	}
+
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	errResults := []reflect.Value{reflect.Zero(reflect.TypeOf("")), reflect.ValueOf(errors.New("boom")).Convert(errType)}
+	if shouldCacheScopedResult(okType, errResults) {
+		t.Fatal("expected a non-nil error result not to be cached")
+	}
+
+	singleReturnType := reflect.TypeOf(func() int { return 0 })
+	if !shouldCacheScopedResult(singleReturnType, []reflect.Value{reflect.ValueOf(42)}) {
+		t.Fatal("expected a single-return fn (no error) to always be cached")
+	}
+}
+
+func TestScopedValueKeyIsStablePerType(t *testing.T) {
+	a := scopedValueKey(reflect.TypeOf(0))
+	b := scopedValueKey(reflect.TypeOf(0))
+	c := scopedValueKey(reflect.TypeOf(""))
+
+	if a != b {
+		t.Fatalf("expected the same type to always produce the same key, got %q vs %q", a, b)
+	}
+
+	if a == c {
+		t.Fatalf("expected different types to produce different keys, both got %q", a)
+	}
+}